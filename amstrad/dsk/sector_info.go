@@ -0,0 +1,71 @@
+package dsk
+
+import (
+	"github.com/pkg/errors"
+
+	"retroio/storage"
+	"retroio/tape"
+)
+
+// sectorInformationBlockSize is the fixed size, in bytes, of a single
+// Sector Information List entry.
+const sectorInformationBlockSize = 8
+
+// SectorInformation is a single entry of a Track's Sector Information List.
+type SectorInformation struct {
+	Track            uint8  // C: track number
+	Side             uint8  // H: side number
+	ID               uint8  // R: sector ID
+	Size             uint8  // N: sector size, as 0x80 << N bytes
+	FDCStatusReg1    uint8  // ST1: FDC status register 1
+	FDCStatusReg2    uint8  // ST2: FDC status register 2
+	ActualDataLength uint16 // actual data length, in bytes (EDSK only; 0 for standard DSK)
+}
+
+// Read the sector information entry.
+// It is expected that the tape pointer is at the correct position for reading.
+func (s *SectorInformation) Read(reader *storage.Reader) error {
+	s.Track = reader.ReadByte()
+	s.Side = reader.ReadByte()
+	s.ID = reader.ReadByte()
+	s.Size = reader.ReadByte()
+	s.FDCStatusReg1 = reader.ReadByte()
+	s.FDCStatusReg2 = reader.ReadByte()
+	s.ActualDataLength = reader.ReadShort()
+
+	return nil
+}
+
+// SectorByteSize returns the size, in bytes, of this sector's data: the
+// EDSK actual data length when present, otherwise 0x80 << Size.
+func (s SectorInformation) SectorByteSize() int {
+	if s.ActualDataLength > 0 {
+		return int(s.ActualDataLength)
+	}
+	return 0x80 << s.Size
+}
+
+// DataRead reads this sector's raw data bytes from reader.
+func (s SectorInformation) DataRead(reader *storage.Reader) ([]byte, error) {
+	if reader.IsEOF() {
+		return nil, errors.New("unexpected end of file while reading sector data")
+	}
+
+	return reader.ReadNextBytes(s.SectorByteSize()), nil
+}
+
+// Describe returns a structured representation of the sector, for JSON/tree output.
+func (s SectorInformation) Describe() tape.Node {
+	return tape.Node{
+		Name: "Sector Information",
+		Fields: map[string]any{
+			"track":              s.Track,
+			"side":               s.Side,
+			"id":                 s.ID,
+			"size":               s.Size,
+			"fdc_status_reg1":    s.FDCStatusReg1,
+			"fdc_status_reg2":    s.FDCStatusReg2,
+			"actual_data_length": s.ActualDataLength,
+		},
+	}
+}
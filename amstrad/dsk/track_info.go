@@ -8,8 +8,8 @@
 // If the track contains different sized sectors, then the data allocated must be the size of the biggest sector.
 // The "sector size" parameter is used to calculate the location of the sector data.
 //
-// * Sector data always follows Track Information Block at offset &100 from the start of the track information block.
-// * Sector data is stored in the same order as the sectors in the sector info block.
+//   - Sector data always follows Track Information Block at offset &100 from the start of the track information block.
+//   - Sector data is stored in the same order as the sectors in the sector info block.
 package dsk
 
 import (
@@ -18,6 +18,7 @@ import (
 	"github.com/pkg/errors"
 
 	"retroio/storage"
+	"retroio/tape"
 )
 
 const (
@@ -27,9 +28,9 @@ const (
 
 // Track information block
 //
-// * "sector size" parameter is used to calculate the location of each sector's data.
-//    Therefore, the data allocated for each sector must be the same.
-// * "number of sectors" is used to identify the number of valid entries in the sector information list.
+//   - "sector size" parameter is used to calculate the location of each sector's data.
+//     Therefore, the data allocated for each sector must be the same.
+//   - "number of sectors" is used to identify the number of valid entries in the sector information list.
 type TrackInformation struct {
 	Identifier   [13]byte            // Identifier: "Track-Info\r\n"
 	Unused1      [3]byte             // unused
@@ -105,6 +106,27 @@ func (t *TrackInformation) readSectorData(reader *storage.Reader) error {
 	return nil
 }
 
+// Describe returns a structured representation of the block, for JSON/tree output.
+func (t TrackInformation) Describe() tape.Node {
+	children := make([]tape.Node, len(t.Sectors))
+	for i, s := range t.Sectors {
+		children[i] = s.Describe()
+	}
+
+	return tape.Node{
+		Name: "Track Information",
+		Fields: map[string]any{
+			"track":         t.Track,
+			"side":          t.Side,
+			"sector_size":   t.SectorSize,
+			"sectors_count": t.SectorsCount,
+			"gap_length":    t.GapLength,
+			"filler_byte":   t.FillerByte,
+		},
+		Children: children,
+	}
+}
+
 func (t TrackInformation) String() string {
 	sectorByteSize := -1
 	if len(t.Sectors) > 0 {
@@ -118,4 +140,4 @@ func (t TrackInformation) String() string {
 	str += fmt.Sprintf("Sector Size:  %d (%d bytes)\n", t.SectorSize, sectorByteSize)
 	str += fmt.Sprintf("Sector Count: %d\n", t.SectorsCount)
 	return str
-}
\ No newline at end of file
+}
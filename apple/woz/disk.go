@@ -0,0 +1,127 @@
+// Package woz decodes WOZ disk images, the preservation-quality format for
+// Apple II (and similar) floppy disks. Unlike sector-based formats such as
+// Amstrad's DSK, WOZ stores the raw magnetic bit-stream of each quarter
+// track, letting callers perform their own GCR/MFM decoding.
+//
+// Both WOZ1 and WOZ2 are supported; the two differ only in the INFO chunk's
+// extra fields and in how the TRKS chunk locates each track's bit data.
+package woz
+
+import (
+	"fmt"
+	"hash/crc32"
+
+	"github.com/pkg/errors"
+
+	"retroio/storage"
+)
+
+const (
+	magicWoz1 = "WOZ1"
+	magicWoz2 = "WOZ2"
+
+	// Every WOZ file starts with: 4-byte magic, 0xFF, then 0x0A, 0x0D, 0x0A.
+	headerSize = 8
+
+	chunkHeaderSize = 8 // 4-byte ASCII ID + 4-byte little-endian size
+)
+
+// Disk is a fully decoded WOZ disk image.
+type Disk struct {
+	Version int // 1 or 2, taken from the magic bytes
+
+	Info   Info
+	TMap   TMap
+	Tracks []Track
+	Meta   []MetaEntry
+}
+
+// Read parses a WOZ1 or WOZ2 disk image. It is expected that the reader
+// pointer is at the start of the file.
+func (d *Disk) Read(reader *storage.Reader) error {
+	// The TRKS chunk in a WOZ2 image addresses its bit-stream data by block
+	// number relative to the start of the *file*, not the chunk data, so the
+	// raw file bytes are captured up front and carried alongside the chunk
+	// payload for that one lookup.
+	fileData := reader.ReadRemainingBytes()
+	file := storage.NewReader(fileData)
+
+	version, payload, err := d.readHeader(file)
+	if err != nil {
+		return err
+	}
+	d.Version = version
+
+	chunks := storage.NewReader(payload)
+
+	for !chunks.IsEOF() {
+		id := string(chunks.ReadNextBytes(4))
+		size := int(chunks.ReadLong())
+		body := chunks.ReadNextBytes(size)
+
+		if err := d.readChunk(id, body, fileData); err != nil {
+			return errors.Wrapf(err, "error reading %q chunk", id)
+		}
+	}
+
+	return nil
+}
+
+// readHeader validates the magic bytes and the CRC-32 of the payload that
+// follows, returning the WOZ version (1 or 2) and that payload.
+func (d *Disk) readHeader(reader *storage.Reader) (int, []byte, error) {
+	magic := string(reader.ReadNextBytes(4))
+	reader.ReadNextBytes(4) // 0xFF, 0x0A, 0x0D, 0x0A
+
+	crc := reader.ReadLong()
+	payload := reader.ReadRemainingBytes()
+
+	if crc32.ChecksumIEEE(payload) != crc {
+		return 0, nil, errors.New("CRC-32 mismatch, file is corrupt")
+	}
+
+	switch magic {
+	case magicWoz1:
+		return 1, payload, nil
+	case magicWoz2:
+		return 2, payload, nil
+	default:
+		return 0, nil, fmt.Errorf("not a WOZ disk image, unknown magic %q", magic)
+	}
+}
+
+// readChunk decodes a single top-level chunk once its ID and body have been
+// read. fileData is the full raw file, needed by TRKS in a WOZ2 image to
+// resolve its block-indirected bit-stream data, since the WOZ2 spec defines
+// a track's starting block as relative to the start of the file.
+func (d *Disk) readChunk(id string, body, fileData []byte) error {
+	reader := storage.NewReader(body)
+
+	switch id {
+	case "INFO":
+		return d.Info.Read(reader, d.Version)
+	case "TMAP":
+		return d.TMap.Read(reader)
+	case "TRKS":
+		tracks, err := readTracks(reader, len(body), fileData, d.Version)
+		if err != nil {
+			return err
+		}
+		d.Tracks = tracks
+	case "META":
+		d.Meta = readMeta(body)
+	}
+
+	return nil
+}
+
+// Track returns the decoded track for the given TMAP quarter-track index,
+// or false if that quarter track is unused or its TMAP entry does not
+// correspond to any track actually present in the TRKS chunk.
+func (d *Disk) Track(quarterTrack int) (Track, bool) {
+	index := d.TMap[quarterTrack]
+	if index == unusedTrack || int(index) >= len(d.Tracks) {
+		return Track{}, false
+	}
+	return d.Tracks[index], true
+}
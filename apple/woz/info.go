@@ -0,0 +1,58 @@
+package woz
+
+import (
+	"strings"
+
+	"retroio/storage"
+)
+
+// Disk types, as given in the INFO chunk.
+const (
+	DiskType525 = 1 // 5.25 inch disk
+	DiskType35  = 2 // 3.5 inch disk
+)
+
+// Info is the decoded INFO chunk, describing the disk type and the
+// imaging/emulation hints needed to correctly play back its tracks.
+//
+// The WOZ2 fields (Sides, BootSectorFormat, OptimalBitTiming,
+// CompatibleHardware, RequiredRAM and LargestTrack) are zero for WOZ1 images.
+type Info struct {
+	Version        uint8  // INFO chunk version, currently 1, 2 or 3
+	DiskType       uint8  // 1 = 5.25 inch, 2 = 3.5 inch
+	WriteProtected bool   // Was the original disk write protected?
+	Synchronized   bool   // Were the tracks imaged with cross-track synchronization?
+	Cleaned        bool   // Have fake bits been removed from MC3470 fake bits?
+	Creator        string // Name of the software that created the file
+
+	Sides              uint8  // WOZ2: number of disk sides
+	BootSectorFormat   uint8  // WOZ2: boot sector format, 0 = unknown
+	OptimalBitTiming   uint8  // WOZ2: standard track bit timing, in 125ns increments
+	CompatibleHardware uint16 // WOZ2: bitfield of compatible Apple II hardware
+	RequiredRAM        uint16 // WOZ2: minimum RAM, in KB, required by the disk
+	LargestTrack       uint16 // WOZ2: number of 512-byte blocks used by the largest track
+}
+
+// Read decodes the INFO chunk. version is the WOZ file version (1 or 2), as
+// the chunk layout differs between them.
+func (i *Info) Read(reader *storage.Reader, version int) error {
+	i.Version = reader.ReadByte()
+	i.DiskType = reader.ReadByte()
+	i.WriteProtected = reader.ReadByte() == 1
+	i.Synchronized = reader.ReadByte() == 1
+	i.Cleaned = reader.ReadByte() == 1
+	i.Creator = strings.TrimRight(string(reader.ReadNextBytes(32)), " ")
+
+	if version < 2 {
+		return nil
+	}
+
+	i.Sides = reader.ReadByte()
+	i.BootSectorFormat = reader.ReadByte()
+	i.OptimalBitTiming = reader.ReadByte()
+	i.CompatibleHardware = reader.ReadShort()
+	i.RequiredRAM = reader.ReadShort()
+	i.LargestTrack = reader.ReadShort()
+
+	return nil
+}
@@ -0,0 +1,33 @@
+package woz
+
+import "strings"
+
+// MetaEntry is a single key/value pair from the META chunk, such as
+// "language\tEnglish" or "requires_ram\t64K".
+type MetaEntry struct {
+	Key   string
+	Value string
+}
+
+// readMeta decodes the META chunk: UTF-8 text, one key/value pair per line,
+// key and value separated by a tab. Order is preserved, as the spec allows
+// duplicate keys (e.g. multiple "side_name" entries).
+func readMeta(body []byte) []MetaEntry {
+	var entries []MetaEntry
+
+	for _, line := range strings.Split(string(body), "\n") {
+		line = strings.TrimRight(line, "\r")
+		if line == "" {
+			continue
+		}
+
+		parts := strings.SplitN(line, "\t", 2)
+		entry := MetaEntry{Key: parts[0]}
+		if len(parts) == 2 {
+			entry.Value = parts[1]
+		}
+		entries = append(entries, entry)
+	}
+
+	return entries
+}
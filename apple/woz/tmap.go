@@ -0,0 +1,23 @@
+package woz
+
+import (
+	"retroio/storage"
+)
+
+// quarterTracks is the number of quarter-track positions a TMAP indexes, 40
+// tracks * 4 quarter-tracks.
+const quarterTracks = 160
+
+// unusedTrack marks a quarter-track position that has no associated track data.
+const unusedTrack = 0xFF
+
+// TMap is the 160-entry quarter-track map. Each entry is either the index of
+// the corresponding Track in Disk.Tracks, or unusedTrack if that
+// quarter-track position was not imaged.
+type TMap [quarterTracks]uint8
+
+// Read decodes the TMAP chunk.
+func (t *TMap) Read(reader *storage.Reader) error {
+	copy(t[:], reader.ReadNextBytes(quarterTracks))
+	return nil
+}
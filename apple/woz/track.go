@@ -0,0 +1,102 @@
+package woz
+
+import (
+	"github.com/pkg/errors"
+
+	"retroio/storage"
+)
+
+// blockSize is the size, in bytes, of the blocks a WOZ2 TRKS entry's
+// starting block/block count pair addresses.
+const blockSize = 512
+
+// woz1BitstreamSize is the fixed size, in bytes, of a WOZ1 track's
+// bit-stream buffer.
+const woz1BitstreamSize = 6646
+
+// woz1TrackEntrySize is the size, in bytes, of one WOZ1 TRKS entry: the
+// bit-stream buffer plus its bytes-used/bit-count/reserved trailer.
+const woz1TrackEntrySize = woz1BitstreamSize + 2 + 2 + 6
+
+// Track holds one quarter track's raw magnetic bit-stream, as decoded from
+// a TRKS entry. It is up to the caller to GCR/MFM decode BitStream.
+type Track struct {
+	bits     []byte
+	bitCount uint32
+}
+
+// BitStream returns the raw bits of this track, along with the number of
+// bits actually used (the bit-stream buffer is padded to a byte boundary).
+func (t Track) BitStream() ([]byte, uint32) {
+	return t.bits, t.bitCount
+}
+
+// readTracks decodes every entry of the TRKS chunk. chunkLength is the size
+// of the TRKS chunk body, needed by WOZ1 images to know how many entries it
+// packs; fileData is the full raw file, needed by WOZ2 images to resolve
+// each entry's starting-block/block-count indirection: the WOZ2 spec
+// addresses blocks from the start of the file, not the start of the TRKS
+// chunk.
+func readTracks(reader *storage.Reader, chunkLength int, fileData []byte, version int) ([]Track, error) {
+	if version == 1 {
+		return readTracksWoz1(reader, chunkLength)
+	}
+	return readTracksWoz2(reader, fileData)
+}
+
+// readTracksWoz1 decodes the WOZ1 TRKS chunk, which packs its entries back
+// to back rather than indexing a fixed 160-entry table like WOZ2, so the
+// entry count is derived from the chunk length instead of quarterTracks.
+func readTracksWoz1(reader *storage.Reader, chunkLength int) ([]Track, error) {
+	var tracks []Track
+
+	count := chunkLength / woz1TrackEntrySize
+	for i := 0; i < count; i++ {
+		bits := reader.ReadNextBytes(woz1BitstreamSize)
+		bytesUsed := reader.ReadShort()
+		bitCount := reader.ReadShort()
+		reader.ReadNextBytes(6) // splice point, splice nibble, splice bit count, reserved
+
+		if int(bytesUsed) > len(bits) {
+			return nil, errors.Errorf("track #%d bytes-used (%d) exceeds its bit-stream buffer (%d)", i, bytesUsed, len(bits))
+		}
+
+		tracks = append(tracks, Track{
+			bits:     bits[:bytesUsed],
+			bitCount: uint32(bitCount),
+		})
+	}
+
+	return tracks, nil
+}
+
+func readTracksWoz2(reader *storage.Reader, fileData []byte) ([]Track, error) {
+	var tracks []Track
+
+	for i := 0; i < quarterTracks; i++ {
+		startingBlock := reader.ReadShort()
+		blockCount := reader.ReadShort()
+		bitCount := reader.ReadLong()
+
+		if startingBlock == 0 {
+			tracks = append(tracks, Track{})
+			continue
+		}
+
+		// Starting block is relative to the start of the file (it covers
+		// the 12-byte WOZ header, the INFO/TMAP chunks, and the TRKS chunk's
+		// own header/TRK-entry table), not the start of any one chunk.
+		start := int(startingBlock) * blockSize
+		end := start + int(blockCount)*blockSize
+		if end > len(fileData) {
+			return nil, errors.Errorf("track #%d bit-stream extends beyond the end of the file", i)
+		}
+
+		tracks = append(tracks, Track{
+			bits:     fileData[start:end],
+			bitCount: bitCount,
+		})
+	}
+
+	return tracks, nil
+}
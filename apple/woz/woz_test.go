@@ -0,0 +1,59 @@
+package woz
+
+import (
+	"testing"
+
+	"retroio/storage"
+)
+
+// TestTMapRead checks that Read copies the 160-byte quarter-track map
+// verbatim.
+func TestTMapRead(t *testing.T) {
+	raw := make([]byte, quarterTracks)
+	raw[0] = 0
+	raw[1] = unusedTrack
+
+	var tm TMap
+	if err := tm.Read(storage.NewReader(raw)); err != nil {
+		t.Fatalf("Read returned error: %v", err)
+	}
+
+	if tm[0] != 0 || tm[1] != unusedTrack {
+		t.Errorf("unexpected TMap contents: %v", tm[:2])
+	}
+}
+
+// TestDiskTrackUnused checks that an unused quarter-track position reports
+// false rather than returning a zero Track as if it were real data.
+func TestDiskTrackUnused(t *testing.T) {
+	d := &Disk{TMap: TMap{0: unusedTrack}}
+
+	if _, ok := d.Track(0); ok {
+		t.Error("expected ok=false for an unused quarter track")
+	}
+}
+
+// TestDiskTrackOutOfRange checks that a TMAP entry pointing past the end of
+// Tracks (a corrupt or truncated file) reports false instead of panicking.
+func TestDiskTrackOutOfRange(t *testing.T) {
+	d := &Disk{TMap: TMap{0: 5}} // no Tracks decoded at all
+
+	if _, ok := d.Track(0); ok {
+		t.Error("expected ok=false for a TMAP entry with no matching track")
+	}
+}
+
+// TestReadTracksWoz1BytesUsedOutOfBounds checks that a WOZ1 TRKS entry whose
+// bytes-used field exceeds its bit-stream buffer is rejected rather than
+// panicking on the slice operation.
+func TestReadTracksWoz1BytesUsedOutOfBounds(t *testing.T) {
+	entry := make([]byte, woz1TrackEntrySize)
+	// bytesUsed, at offset woz1BitstreamSize, little-endian, larger than the buffer.
+	entry[woz1BitstreamSize] = 0xFF
+	entry[woz1BitstreamSize+1] = 0xFF
+
+	_, err := readTracksWoz1(storage.NewReader(entry), len(entry))
+	if err == nil {
+		t.Fatal("expected an error for a bytes-used value exceeding the bit-stream buffer")
+	}
+}
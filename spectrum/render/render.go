@@ -0,0 +1,248 @@
+package render
+
+import (
+	"bytes"
+	"compress/zlib"
+	"encoding/binary"
+	"io"
+
+	"github.com/pkg/errors"
+
+	"retroio/spectrum/tzx"
+	"retroio/spectrum/tzx/blocks"
+)
+
+// spectrumClockHz is the Z80 clock speed of a standard ZX Spectrum, used to
+// convert T-states into samples.
+const spectrumClockHz = 3_500_000
+
+// Standard-speed timings, in T-states, as given in the TZX specification.
+const (
+	standardPilotPulse  = 2168
+	standardSyncFirst   = 667
+	standardSyncSecond  = 735
+	standardBitZero     = 855
+	standardBitOne      = 1710
+	standardPilotHeader = 8063 // pilot pulses before a header block (flag byte 0x00)
+	standardPilotData   = 3223 // pilot pulses before a data block (any other flag byte)
+)
+
+// Render walks a resolved block stream and writes it to w as a WAV file
+// containing mono PCM audio at the given sample rate and bit depth.
+func Render(w io.Writer, stream tzx.Iterator, rate SampleRate, depth BitDepth) error {
+	s := &synthesizer{rate: rate, depth: depth, level: true}
+
+	for {
+		block, ok := stream.Next()
+		if !ok {
+			break
+		}
+
+		if err := s.render(block); err != nil {
+			return errors.Wrapf(err, "error rendering %q block", block.Name())
+		}
+	}
+
+	if err := writeWavHeader(w, rate, depth, s.byteLength()); err != nil {
+		return err
+	}
+	_, err := w.Write(s.samples)
+	return err
+}
+
+// synthesizer accumulates PCM samples as it walks the resolved block stream,
+// tracking the current signal polarity so each pulse can toggle it.
+type synthesizer struct {
+	rate    SampleRate
+	depth   BitDepth
+	level   bool // current output polarity: true = high, false = low
+	samples []byte
+}
+
+func (s *synthesizer) render(block tzx.Block) error {
+	switch b := block.(type) {
+	case *blocks.StandardSpeedData:
+		pilotCount := standardPilotData
+		if len(b.Data) > 0 && b.Data[0] == 0x00 {
+			pilotCount = standardPilotHeader
+		}
+		s.renderDataBlock(b.Data, standardPilotPulse, pilotCount, standardSyncFirst, standardSyncSecond, standardBitZero, standardBitOne, 8, b.Pause)
+	case *blocks.TurboSpeedData:
+		s.renderDataBlock(b.Data, int(b.PilotPulse), int(b.PilotTone), int(b.SyncFirst), int(b.SyncSecond), int(b.Zero), int(b.One), b.UsedBits, b.Pause)
+	case *blocks.PureTone:
+		for i := uint16(0); i < b.PulseCount; i++ {
+			s.pulse(int(b.PulseLength))
+		}
+	case *blocks.SequenceOfPulses:
+		for _, length := range b.Pulses {
+			s.pulse(int(length))
+		}
+	case *blocks.PureData:
+		s.renderBits(b.Data, int(b.Zero), int(b.One), b.UsedBits)
+		s.pause(b.Pause)
+	case *blocks.DirectRecording:
+		s.renderDirectRecording(b)
+	case *blocks.PauseTheTapeCommand:
+		s.pause(b.Duration)
+	case *blocks.SetSignalLevel:
+		s.level = b.Level == blocks.SignalLevelHigh
+	case *blocks.CswRecording:
+		return s.renderCSW(b)
+	}
+
+	return nil
+}
+
+// renderDataBlock renders a pilot tone, sync pulses and the data bits of a
+// StandardSpeedData/TurboSpeedData block, followed by its trailing pause.
+func (s *synthesizer) renderDataBlock(data []byte, pilotPulse, pilotCount, syncFirst, syncSecond, zero, one int, usedBits uint8, pause uint16) {
+	for i := 0; i < pilotCount; i++ {
+		s.pulse(pilotPulse)
+	}
+	s.pulse(syncFirst)
+	s.pulse(syncSecond)
+
+	s.renderBits(data, zero, one, usedBits)
+	s.pause(pause)
+}
+
+// renderBits renders each bit of data as two pulses (a full cycle), using
+// zero/one T-state lengths. usedBits gives the number of valid bits in the
+// last byte; all bits of every other byte are used.
+func (s *synthesizer) renderBits(data []byte, zero, one int, usedBits uint8) {
+	for i, b := range data {
+		bits := 8
+		if i == len(data)-1 && usedBits > 0 {
+			bits = int(usedBits)
+		}
+
+		for bit := 0; bit < bits; bit++ {
+			length := zero
+			if b&(0x80>>uint(bit)) != 0 {
+				length = one
+			}
+			s.pulse(length)
+			s.pulse(length)
+		}
+	}
+}
+
+// renderDirectRecording renders a raw, one-bit-per-sample recording, each
+// bit held for TStatesPerSample.
+func (s *synthesizer) renderDirectRecording(b *blocks.DirectRecording) {
+	bits := len(b.Data) * 8
+	if b.UsedBits > 0 {
+		bits = (len(b.Data)-1)*8 + int(b.UsedBits)
+	}
+
+	for i := 0; i < bits; i++ {
+		byteIndex := i / 8
+		bitIndex := uint(i % 8)
+		high := b.Data[byteIndex]&(0x80>>bitIndex) != 0
+
+		s.level = high
+		s.hold(int(b.TStatesPerSample))
+	}
+
+	s.pause(b.Pause)
+}
+
+// renderCSW renders a CSW pulse stream, decoded from RLE or Z-RLE
+// (zlib-compressed RLE) as indicated by the block's compression type.
+func (s *synthesizer) renderCSW(b *blocks.CswRecording) error {
+	data := b.Data
+
+	if b.CompressionType == blocks.CswCompressionZRLE {
+		r, err := zlib.NewReader(bytes.NewReader(data))
+		if err != nil {
+			return errors.Wrap(err, "CSW Z-RLE stream has invalid zlib header")
+		}
+		defer r.Close()
+
+		data, err = io.ReadAll(r)
+		if err != nil {
+			return errors.Wrap(err, "CSW Z-RLE stream is corrupt")
+		}
+	}
+
+	for i := 0; i < len(data); i++ {
+		count := data[i]
+
+		var pulseLength uint32
+		if count == 0 {
+			// A 0 byte means the pulse length is given by the following
+			// 4 bytes (little-endian), rather than the byte itself.
+			if i+4 >= len(data) {
+				return errors.New("CSW RLE stream truncated: missing extended pulse length")
+			}
+			pulseLength = binary.LittleEndian.Uint32(data[i+1 : i+5])
+			i += 4
+		} else {
+			pulseLength = uint32(count)
+		}
+
+		s.pulse(int(float64(pulseLength) * spectrumClockHz / float64(b.SampleRate)))
+	}
+
+	s.pause(b.Pause)
+	return nil
+}
+
+// pulse holds the current level for tstates T-states, then toggles it -
+// this is what the TZX spec calls a single "pulse".
+func (s *synthesizer) pulse(tstates int) {
+	s.hold(tstates)
+	s.level = !s.level
+}
+
+// hold outputs tstates worth of samples at the current level, without
+// toggling it.
+func (s *synthesizer) hold(tstates int) {
+	n := tstatesToSamples(tstates, s.rate)
+	for i := 0; i < n; i++ {
+		s.appendSample()
+	}
+}
+
+// pause outputs milliseconds worth of silence (low level).
+func (s *synthesizer) pause(milliseconds uint16) {
+	if milliseconds == 0 {
+		return
+	}
+
+	wasHigh := s.level
+	s.level = false
+	n := int(s.rate) * int(milliseconds) / 1000
+	for i := 0; i < n; i++ {
+		s.appendSample()
+	}
+	s.level = wasHigh
+}
+
+func (s *synthesizer) appendSample() {
+	if s.depth == BitDepth16 {
+		value := int16(0)
+		if s.level {
+			value = 32767
+		} else {
+			value = -32768
+		}
+		s.samples = append(s.samples, byte(value), byte(value>>8))
+	} else {
+		value := byte(0)
+		if s.level {
+			value = 255
+		}
+		s.samples = append(s.samples, value)
+	}
+}
+
+func (s *synthesizer) byteLength() int {
+	return len(s.samples)
+}
+
+// tstatesToSamples converts a duration given in Z80 T-states, at the
+// standard 3.5MHz Spectrum clock, into a number of samples at rate.
+func tstatesToSamples(tstates int, rate SampleRate) int {
+	return tstates * int(rate) / spectrumClockHz
+}
@@ -0,0 +1,83 @@
+package render
+
+import (
+	"bytes"
+	"encoding/binary"
+	"testing"
+
+	"retroio/spectrum/tzx"
+	"retroio/spectrum/tzx/blocks"
+)
+
+// sliceIterator adapts a fixed slice of blocks into a tzx.Iterator, for
+// feeding a known sequence into Render without needing a real tape.
+type sliceIterator struct {
+	blocks []tzx.Block
+	pos    int
+}
+
+func (s *sliceIterator) Next() (tzx.Block, bool) {
+	if s.pos >= len(s.blocks) {
+		return nil, false
+	}
+	b := s.blocks[s.pos]
+	s.pos++
+	return b, true
+}
+
+// TestRenderWavHeader checks that Render writes a well-formed mono PCM WAV
+// header describing the requested sample rate and bit depth.
+func TestRenderWavHeader(t *testing.T) {
+	var out bytes.Buffer
+	if err := Render(&out, &sliceIterator{}, SampleRate44100, BitDepth16); err != nil {
+		t.Fatalf("Render returned error: %v", err)
+	}
+
+	data := out.Bytes()
+	if !bytes.Equal(data[0:4], []byte("RIFF")) || !bytes.Equal(data[8:12], []byte("WAVE")) {
+		t.Fatalf("missing RIFF/WAVE header: % X", data[:12])
+	}
+
+	rate := binary.LittleEndian.Uint32(data[24:28])
+	if rate != uint32(SampleRate44100) {
+		t.Errorf("expected sample rate %d, got %d", SampleRate44100, rate)
+	}
+
+	bitsPerSample := binary.LittleEndian.Uint16(data[34:36])
+	if bitsPerSample != uint16(BitDepth16) {
+		t.Errorf("expected bit depth %d, got %d", BitDepth16, bitsPerSample)
+	}
+}
+
+// TestRenderPureTone checks that a PureTone block renders PulseCount pulses,
+// each held for PulseLength T-states, as PCM samples.
+func TestRenderPureTone(t *testing.T) {
+	block := &blocks.PureTone{PulseLength: 1000, PulseCount: 4}
+
+	var out bytes.Buffer
+	if err := Render(&out, &sliceIterator{blocks: []tzx.Block{block}}, SampleRate44100, BitDepth8); err != nil {
+		t.Fatalf("Render returned error: %v", err)
+	}
+
+	wantSamples := tstatesToSamples(int(block.PulseLength), SampleRate44100) * int(block.PulseCount)
+	gotSamples := out.Len() - 44 // WAV header is 44 bytes for a PCM file
+	if gotSamples != wantSamples {
+		t.Errorf("expected %d sample bytes, got %d", wantSamples, gotSamples)
+	}
+}
+
+// TestRenderCSWTruncatedExtendedPulse checks that a CSW RLE stream whose
+// extended (0-prefixed) pulse length is cut off is reported as an error
+// rather than read out of bounds.
+func TestRenderCSWTruncatedExtendedPulse(t *testing.T) {
+	block := &blocks.CswRecording{
+		SampleRate:      44100,
+		CompressionType: blocks.CswCompressionRLE,
+		Data:            []byte{0x00, 0x01, 0x02}, // extended marker, only 2 of 4 length bytes
+	}
+
+	err := Render(&bytes.Buffer{}, &sliceIterator{blocks: []tzx.Block{block}}, SampleRate44100, BitDepth8)
+	if err == nil {
+		t.Fatal("expected an error for a truncated CSW extended pulse length")
+	}
+}
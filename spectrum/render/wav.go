@@ -0,0 +1,62 @@
+// Package render synthesises playable PCM audio from a resolved TZX block
+// stream, so a tape can be listened to (or fed to a real Spectrum) without
+// needing the original hardware loader.
+package render
+
+import (
+	"encoding/binary"
+	"io"
+
+	"github.com/pkg/errors"
+)
+
+// SampleRate is the number of PCM samples produced per second of audio.
+type SampleRate int
+
+// Supported output sample rates.
+const (
+	SampleRate44100 SampleRate = 44100
+	SampleRate48000 SampleRate = 48000
+)
+
+// BitDepth is the number of bits used to represent each PCM sample.
+type BitDepth int
+
+// Supported output bit depths.
+const (
+	BitDepth8  BitDepth = 8
+	BitDepth16 BitDepth = 16
+)
+
+// writeWavHeader writes a standard mono PCM WAV header for dataLength bytes
+// of sample data that will follow.
+func writeWavHeader(w io.Writer, rate SampleRate, depth BitDepth, dataLength int) error {
+	const numChannels = 1
+
+	byteRate := int(rate) * numChannels * int(depth) / 8
+	blockAlign := numChannels * int(depth) / 8
+
+	fields := []any{
+		[4]byte{'R', 'I', 'F', 'F'},
+		uint32(36 + dataLength),
+		[4]byte{'W', 'A', 'V', 'E'},
+		[4]byte{'f', 'm', 't', ' '},
+		uint32(16), // fmt chunk size
+		uint16(1),  // PCM
+		uint16(numChannels),
+		uint32(rate),
+		uint32(byteRate),
+		uint16(blockAlign),
+		uint16(depth),
+		[4]byte{'d', 'a', 't', 'a'},
+		uint32(dataLength),
+	}
+
+	for _, field := range fields {
+		if err := binary.Write(w, binary.LittleEndian, field); err != nil {
+			return errors.Wrap(err, "error writing WAV header")
+		}
+	}
+
+	return nil
+}
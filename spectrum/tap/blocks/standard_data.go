@@ -0,0 +1,72 @@
+package blocks
+
+import (
+	"fmt"
+
+	"retroio/storage"
+)
+
+// StandardData is a length-prefixed standard-speed data block, as used in
+// both .TAP files and a TZX StandardSpeedData (0x10) block's payload.
+//
+// Layout: WORD length (of Flag + Data + Checksum), BYTE flag, BYTE[N] data,
+// BYTE checksum (XOR of Flag and all data bytes).
+type StandardData struct {
+	Length   uint16 // WORD     Length of the following flag, data and checksum bytes
+	Flag     uint8  // BYTE     Flag byte (0x00 header, 0xFF data, anything else custom)
+	Data     []byte // BYTE[N]  Data bytes
+	Checksum uint8  // BYTE     XOR of Flag and all Data bytes
+
+	truncated bool // set by Read when the payload was too short for a flag and checksum byte
+}
+
+// Read the tape and extract the data. It is expected that the tape pointer
+// is at the correct position for reading.
+func (s *StandardData) Read(reader *storage.Reader) {
+	s.Length = reader.ReadShort()
+
+	payload := reader.ReadNextBytes(int(s.Length))
+	if len(payload) < 2 {
+		// Too short to contain both a flag and checksum byte - a corrupt or
+		// cut-off file. Leave Flag/Data/Checksum zeroed and let the caller
+		// find out via Truncated() rather than panicking below.
+		s.truncated = true
+		return
+	}
+
+	s.Flag = payload[0]
+	s.Data = payload[1 : len(payload)-1]
+	s.Checksum = payload[len(payload)-1]
+}
+
+// Truncated reports whether the block's length prefix was too short to
+// contain a flag and checksum byte, in which case Flag/Data/Checksum were
+// left zeroed rather than read.
+func (s StandardData) Truncated() bool {
+	return s.truncated
+}
+
+// ChecksumValid reports whether the trailing checksum byte matches the XOR
+// of the flag and data bytes.
+func (s StandardData) ChecksumValid() bool {
+	sum := s.Flag
+	for _, b := range s.Data {
+		sum ^= b
+	}
+	return sum == s.Checksum
+}
+
+// Id of the block, matching the TZX StandardSpeedData block it is equivalent to.
+func (s StandardData) Id() uint8 {
+	return 0x10
+}
+
+// Name of the block.
+func (s StandardData) Name() string {
+	return "Standard Data"
+}
+
+// ToString returns a human readable string of the block data
+func (s StandardData) ToString() string {
+	return fmt.Sprintf("%s: flag 0x%02X, %d bytes", s.Name(), s.Flag, len(s.Data))
+}
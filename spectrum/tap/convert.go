@@ -0,0 +1,26 @@
+package tap
+
+import (
+	"retroio/spectrum/tap/blocks"
+	tzxblocks "retroio/spectrum/tzx/blocks"
+)
+
+// ToTZX converts a list of TAP blocks into their TZX StandardSpeedData
+// (0x10) equivalent. Every TAP block can be represented in TZX, so this
+// never fails.
+func ToTZX(tapBlocks []*blocks.StandardData) []*tzxblocks.StandardSpeedData {
+	var tzxBlocks []*tzxblocks.StandardSpeedData
+
+	for _, block := range tapBlocks {
+		payload := append([]byte{block.Flag}, block.Data...)
+		payload = append(payload, block.Checksum)
+
+		tzxBlocks = append(tzxBlocks, &tzxblocks.StandardSpeedData{
+			Pause:  1000,
+			Length: uint16(len(payload)),
+			Data:   payload,
+		})
+	}
+
+	return tzxBlocks
+}
@@ -0,0 +1,62 @@
+// Package tap reads and writes .TAP files, the simpler ZX Spectrum tape
+// container: a plain sequence of length-prefixed standard-speed data blocks.
+package tap
+
+import (
+	"fmt"
+
+	"retroio/spectrum/tap/blocks"
+	"retroio/storage"
+)
+
+// Diagnostic describes a non-fatal problem found while reading a TAP file,
+// such as a block whose trailing checksum byte doesn't match its data.
+type Diagnostic struct {
+	BlockIndex int
+	Message    string
+}
+
+func (d Diagnostic) String() string {
+	return fmt.Sprintf("block #%d: %s", d.BlockIndex, d.Message)
+}
+
+// Reader reads the sequence of StandardData blocks that make up a TAP file.
+type Reader struct {
+	reader *storage.Reader
+
+	Blocks      []*blocks.StandardData
+	Diagnostics []Diagnostic
+}
+
+// NewReader returns a Reader ready to read blocks from reader.
+func NewReader(reader *storage.Reader) *Reader {
+	return &Reader{reader: reader}
+}
+
+// Read consumes the rest of the tape, collecting every StandardData block it
+// finds. Checksum mismatches and truncated length prefixes are recorded as
+// Diagnostics rather than returned as errors, as a single bad block
+// shouldn't prevent reading the rest of the tape.
+func (r *Reader) Read() error {
+	for !r.reader.IsEOF() {
+		block := &blocks.StandardData{}
+		block.Read(r.reader)
+
+		switch {
+		case block.Truncated():
+			r.Diagnostics = append(r.Diagnostics, Diagnostic{
+				BlockIndex: len(r.Blocks),
+				Message:    "truncated block: length prefix too short for a flag and checksum byte",
+			})
+		case !block.ChecksumValid():
+			r.Diagnostics = append(r.Diagnostics, Diagnostic{
+				BlockIndex: len(r.Blocks),
+				Message:    "checksum mismatch",
+			})
+		}
+
+		r.Blocks = append(r.Blocks, block)
+	}
+
+	return nil
+}
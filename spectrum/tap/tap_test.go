@@ -0,0 +1,126 @@
+package tap
+
+import (
+	"bytes"
+	"testing"
+
+	"retroio/spectrum/tap/blocks"
+	"retroio/storage"
+)
+
+// encode builds the raw bytes of a single TAP block: a length-prefixed
+// flag/data/checksum payload, as Write and Read expect.
+func encode(flag uint8, data []byte, checksum uint8) []byte {
+	payload := append([]byte{flag}, data...)
+	payload = append(payload, checksum)
+
+	raw := []byte{byte(len(payload)), byte(len(payload) >> 8)}
+	return append(raw, payload...)
+}
+
+// TestReaderReadsBlocks checks that Read collects every StandardData block
+// in a well-formed TAP file, without raising a diagnostic for either.
+func TestReaderReadsBlocks(t *testing.T) {
+	raw := append(encode(0x00, []byte{0x01, 0x02}, 0x00^0x01^0x02), encode(0xFF, []byte{0xAA}, 0xFF^0xAA)...)
+
+	r := NewReader(storage.NewReader(raw))
+	if err := r.Read(); err != nil {
+		t.Fatalf("Read returned error: %v", err)
+	}
+
+	if len(r.Blocks) != 2 {
+		t.Fatalf("expected 2 blocks, got %d", len(r.Blocks))
+	}
+	if len(r.Diagnostics) != 0 {
+		t.Errorf("expected no diagnostics, got %v", r.Diagnostics)
+	}
+}
+
+// TestReaderChecksumMismatch checks that a block with a bad checksum byte is
+// still read, but recorded as a Diagnostic rather than an error.
+func TestReaderChecksumMismatch(t *testing.T) {
+	raw := encode(0x00, []byte{0x01, 0x02}, 0xFF)
+
+	r := NewReader(storage.NewReader(raw))
+	if err := r.Read(); err != nil {
+		t.Fatalf("Read returned error: %v", err)
+	}
+
+	if len(r.Blocks) != 1 {
+		t.Fatalf("expected 1 block, got %d", len(r.Blocks))
+	}
+	if len(r.Diagnostics) != 1 {
+		t.Fatalf("expected 1 diagnostic, got %v", r.Diagnostics)
+	}
+	if r.Diagnostics[0].Message != "checksum mismatch" {
+		t.Errorf("expected a checksum mismatch diagnostic, got %q", r.Diagnostics[0].Message)
+	}
+}
+
+// TestReaderTruncatedBlock checks that a length prefix too short for a flag
+// and checksum byte is recorded as a Diagnostic instead of panicking.
+func TestReaderTruncatedBlock(t *testing.T) {
+	raw := []byte{0x01, 0x00, 0xAA} // length 1, one payload byte
+
+	r := NewReader(storage.NewReader(raw))
+	if err := r.Read(); err != nil {
+		t.Fatalf("Read returned error: %v", err)
+	}
+
+	if len(r.Blocks) != 1 {
+		t.Fatalf("expected 1 block, got %d", len(r.Blocks))
+	}
+	if len(r.Diagnostics) != 1 || r.Diagnostics[0].Message == "" {
+		t.Fatalf("expected a truncated-block diagnostic, got %v", r.Diagnostics)
+	}
+}
+
+// TestWriteReadRoundTrip checks that blocks written with Write can be read
+// back with Reader to produce identical blocks.
+func TestWriteReadRoundTrip(t *testing.T) {
+	original := []*blocks.StandardData{
+		{Flag: 0x00, Data: []byte{0x01, 0x02, 0x03}, Checksum: 0x00 ^ 0x01 ^ 0x02 ^ 0x03},
+		{Flag: 0xFF, Data: []byte{0xAA, 0xBB}, Checksum: 0xFF ^ 0xAA ^ 0xBB},
+	}
+
+	var out bytes.Buffer
+	if err := Write(&out, original); err != nil {
+		t.Fatalf("Write returned error: %v", err)
+	}
+
+	r := NewReader(storage.NewReader(out.Bytes()))
+	if err := r.Read(); err != nil {
+		t.Fatalf("Read returned error: %v", err)
+	}
+
+	if len(r.Blocks) != len(original) {
+		t.Fatalf("expected %d blocks, got %d", len(original), len(r.Blocks))
+	}
+	for i, block := range r.Blocks {
+		if block.Flag != original[i].Flag || !bytes.Equal(block.Data, original[i].Data) || block.Checksum != original[i].Checksum {
+			t.Errorf("block #%d does not match original:\n got:  %#v\n want: %#v", i, block, original[i])
+		}
+	}
+}
+
+// TestToTZX checks that ToTZX wraps each TAP block's flag/data/checksum
+// payload as a TZX StandardSpeedData block.
+func TestToTZX(t *testing.T) {
+	tapBlocks := []*blocks.StandardData{
+		{Flag: 0x00, Data: []byte{0x01, 0x02}, Checksum: 0x03},
+	}
+
+	tzxBlocks := ToTZX(tapBlocks)
+
+	if len(tzxBlocks) != 1 {
+		t.Fatalf("expected 1 block, got %d", len(tzxBlocks))
+	}
+
+	want := []byte{0x00, 0x01, 0x02, 0x03}
+	if !bytes.Equal(tzxBlocks[0].Data, want) {
+		t.Errorf("expected TZX payload % X, got % X", want, tzxBlocks[0].Data)
+	}
+	if tzxBlocks[0].Length != uint16(len(want)) {
+		t.Errorf("expected Length %d, got %d", len(want), tzxBlocks[0].Length)
+	}
+}
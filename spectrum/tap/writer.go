@@ -0,0 +1,27 @@
+package tap
+
+import (
+	"encoding/binary"
+	"io"
+
+	"github.com/pkg/errors"
+
+	"retroio/spectrum/tap/blocks"
+)
+
+// Write encodes the given blocks as a .TAP file.
+func Write(w io.Writer, tapBlocks []*blocks.StandardData) error {
+	for i, block := range tapBlocks {
+		payload := append([]byte{block.Flag}, block.Data...)
+		payload = append(payload, block.Checksum)
+
+		if err := binary.Write(w, binary.LittleEndian, uint16(len(payload))); err != nil {
+			return errors.Wrapf(err, "error writing length of block #%d", i)
+		}
+		if _, err := w.Write(payload); err != nil {
+			return errors.Wrapf(err, "error writing block #%d", i)
+		}
+	}
+
+	return nil
+}
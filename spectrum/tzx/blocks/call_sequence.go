@@ -2,8 +2,10 @@ package blocks
 
 import (
 	"fmt"
+	"io"
 
 	"retroio/storage"
+	"retroio/tape"
 )
 
 // CallSequence
@@ -49,6 +51,34 @@ func (c CallSequence) ToString() string {
 	return str
 }
 
+// Write encodes the block, including its ID byte, to w.
+func (c CallSequence) Write(w io.Writer) error {
+	if err := writeId(w, c.Id()); err != nil {
+		return err
+	}
+	if err := writeShort(w, c.Count); err != nil {
+		return err
+	}
+	for _, b := range c.Blocks {
+		if err := writeShort(w, b); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Describe returns a structured representation of the block, for JSON/tree output.
+func (c CallSequence) Describe() tape.Node {
+	return tape.Node{
+		ID:   int(c.Id()),
+		Name: c.Name(),
+		Fields: map[string]any{
+			"count":  c.Count,
+			"blocks": c.Blocks,
+		},
+	}
+}
+
 // ReturnFromSequence
 // ID: 27h (39d)
 // This block indicates the end of the Called Sequence. The next block played will be the block after
@@ -73,4 +103,9 @@ func (r ReturnFromSequence) Name() string {
 // ToString returns a human readable string of the block data
 func (r ReturnFromSequence) ToString() string {
 	return r.Name()
-}
\ No newline at end of file
+}
+
+// Write encodes the block, including its ID byte, to w.
+func (r ReturnFromSequence) Write(w io.Writer) error {
+	return writeId(w, r.Id())
+}
@@ -0,0 +1,80 @@
+package blocks
+
+import (
+	"fmt"
+	"io"
+
+	"retroio/storage"
+)
+
+// Compression types used by CswRecording.
+const (
+	CswCompressionRLE  = 0x01
+	CswCompressionZRLE = 0x02
+)
+
+// CswRecording
+// ID: 18h (24d)
+// This block contains a sequence of raw pulses encoded in CSW (Compressed
+// Square Wave) format, either RLE or Z-RLE compressed.
+type CswRecording struct {
+	Length          uint32 // DWORD  Block length (without these four bytes)
+	Pause           uint16 // WORD   Pause after this block (ms)
+	SampleRate      uint32 // BYTE[3] Sampling rate
+	CompressionType uint8  // BYTE   Compression type: 1 = RLE, 2 = Z-RLE
+	PulseCount      uint32 // DWORD  Number of stored pulses (after decompression)
+	Data            []byte // BYTE[N] CSW data, encoded according to the CSW format specification
+}
+
+// Read the tape and extract the data.
+// It is expected that the tape pointer is at the correct position for reading.
+func (c *CswRecording) Read(reader *storage.Reader) {
+	c.Length = reader.ReadLong()
+	c.Pause = reader.ReadShort()
+	c.SampleRate = reader.Read3ByteLong()
+	c.CompressionType = reader.ReadByte()
+	c.PulseCount = reader.ReadLong()
+
+	// the block length includes the 10 bytes already read above
+	dataLength := int(c.Length) - 10
+	c.Data = reader.ReadNextBytes(dataLength)
+}
+
+// Id of the block as given in the TZX specification, written as a hexadecimal number.
+func (c CswRecording) Id() uint8 {
+	return 0x18
+}
+
+// Name of the block as given in the TZX specification.
+func (c CswRecording) Name() string {
+	return "CSW Recording"
+}
+
+// ToString returns a human readable string of the block data
+func (c CswRecording) ToString() string {
+	return fmt.Sprintf("%s: %d pulses", c.Name(), c.PulseCount)
+}
+
+// Write encodes the block, including its ID byte, to w.
+func (c CswRecording) Write(w io.Writer) error {
+	if err := writeId(w, c.Id()); err != nil {
+		return err
+	}
+	if err := writeLong(w, c.Length); err != nil {
+		return err
+	}
+	if err := writeShort(w, c.Pause); err != nil {
+		return err
+	}
+	if err := write3ByteLong(w, c.SampleRate); err != nil {
+		return err
+	}
+	if err := writeByte(w, c.CompressionType); err != nil {
+		return err
+	}
+	if err := writeLong(w, c.PulseCount); err != nil {
+		return err
+	}
+	_, err := w.Write(c.Data)
+	return err
+}
@@ -0,0 +1,71 @@
+package blocks
+
+import (
+	"fmt"
+	"io"
+
+	"retroio/storage"
+	"retroio/tape"
+)
+
+// CustomInfo
+// ID: 35h (53d)
+// This block can be used to save any information you want. For example, it
+// might contain some information written by a utility, extra settings
+// required by a particular emulator, or even poke data.
+type CustomInfo struct {
+	Identification [10]byte // CHAR[10]  Identification string (in ASCII)
+	Length         uint32   // L DWORD   Length of the custom info
+	Info           []uint8  // BYTE[L]   Custom info
+}
+
+// Read the tape and extract the data.
+// It is expected that the tape pointer is at the correct position for reading.
+func (c *CustomInfo) Read(reader *storage.Reader) {
+	copy(c.Identification[:], reader.ReadNextBytes(10))
+	c.Length = reader.ReadLong()
+	c.Info = reader.ReadNextBytes(int(c.Length))
+}
+
+// Id of the block as given in the TZX specification, written as a hexadecimal number.
+func (c CustomInfo) Id() uint8 {
+	return 0x35
+}
+
+// Name of the block as given in the TZX specification.
+func (c CustomInfo) Name() string {
+	return "Custom Info"
+}
+
+// ToString returns a human readable string of the block data
+func (c CustomInfo) ToString() string {
+	return fmt.Sprintf("%s: %s", c.Name(), c.Identification)
+}
+
+// Write encodes the block, including its ID byte, to w. The Length field is
+// derived from Info, so callers can rewrite Info and re-encode directly.
+func (c CustomInfo) Write(w io.Writer) error {
+	if err := writeId(w, c.Id()); err != nil {
+		return err
+	}
+	if _, err := w.Write(c.Identification[:]); err != nil {
+		return err
+	}
+	if err := writeLong(w, uint32(len(c.Info))); err != nil {
+		return err
+	}
+	_, err := w.Write(c.Info)
+	return err
+}
+
+// Describe returns a structured representation of the block, for JSON/tree output.
+func (c CustomInfo) Describe() tape.Node {
+	return tape.Node{
+		ID:   int(c.Id()),
+		Name: c.Name(),
+		Fields: map[string]any{
+			"identification": string(c.Identification[:]),
+			"length":         c.Length,
+		},
+	}
+}
@@ -0,0 +1,99 @@
+package blocks
+
+import (
+	"fmt"
+	"io"
+
+	"retroio/storage"
+	"retroio/tape"
+)
+
+// HardwareInfo describes a single machine or piece of hardware listed by a
+// HardwareType block.
+type HardwareInfo struct {
+	Type        uint8 // BYTE  Hardware type
+	Id          uint8 // BYTE  Hardware ID
+	Information uint8 // BYTE  Hardware information:
+	//                           00 - The tape RUNS on this machine or with this hardware,
+	//                                but may or may not use the hardware or special features of the machine.
+	//                           01 - The tape USES the hardware or special features of the machine,
+	//                                such as extra memory or a sound chip.
+	//                           02 - The tape RUNS but it DOESN'T use the hardware
+	//                                or special features of the machine.
+	//                           03 - The tape DOESN'T RUN on this machine or with this hardware.
+}
+
+// HardwareType
+// ID: 33h (51d)
+// This blocks contains information about the hardware that the programs on
+// this tape use. Please include only machines and hardware for which you are
+// 100% sure that it either runs (or doesn't run) on or with, or you know it
+// uses (or doesn't use) the hardware or special features of that machine.
+type HardwareType struct {
+	TypeCount uint8          // N BYTE     Number of machines and hardware types for which info is supplied
+	Machines  []HardwareInfo // HWINFO[N]  List of machines and hardware
+}
+
+// Read the tape and extract the data.
+// It is expected that the tape pointer is at the correct position for reading.
+func (h *HardwareType) Read(reader *storage.Reader) {
+	h.TypeCount = reader.ReadByte()
+
+	h.Machines = make([]HardwareInfo, h.TypeCount)
+	for i := range h.Machines {
+		h.Machines[i] = HardwareInfo{
+			Type:        reader.ReadByte(),
+			Id:          reader.ReadByte(),
+			Information: reader.ReadByte(),
+		}
+	}
+}
+
+// Id of the block as given in the TZX specification, written as a hexadecimal number.
+func (h HardwareType) Id() uint8 {
+	return 0x33
+}
+
+// Name of the block as given in the TZX specification.
+func (h HardwareType) Name() string {
+	return "Hardware Type"
+}
+
+// ToString returns a human readable string of the block data
+func (h HardwareType) ToString() string {
+	return fmt.Sprintf("%s: %d machine(s)", h.Name(), h.TypeCount)
+}
+
+// Write encodes the block, including its ID byte, to w.
+func (h HardwareType) Write(w io.Writer) error {
+	if err := writeId(w, h.Id()); err != nil {
+		return err
+	}
+	if err := writeByte(w, h.TypeCount); err != nil {
+		return err
+	}
+	for _, m := range h.Machines {
+		if err := writeByte(w, m.Type); err != nil {
+			return err
+		}
+		if err := writeByte(w, m.Id); err != nil {
+			return err
+		}
+		if err := writeByte(w, m.Information); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Describe returns a structured representation of the block, for JSON/tree output.
+func (h HardwareType) Describe() tape.Node {
+	return tape.Node{
+		ID:   int(h.Id()),
+		Name: h.Name(),
+		Fields: map[string]any{
+			"typeCount": h.TypeCount,
+			"machines":  h.Machines,
+		},
+	}
+}
@@ -0,0 +1,46 @@
+package blocks
+
+import (
+	"fmt"
+	"io"
+
+	"retroio/storage"
+)
+
+// JumpTo
+// ID: 23h (35d)
+// This block will enable you to jump from one block to another within the file.
+// The value is relative to the block number, so a value of 0 would be a jump to
+// itself, 1 to the next block, etc. This is like the GOTO statement.
+type JumpTo struct {
+	Offset uint16 // WORD  Relative jump value (e.g. -1 = previous block, 0 = this one, 1 = next block, etc.)
+}
+
+// Read the tape and extract the data.
+// It is expected that the tape pointer is at the correct position for reading.
+func (j *JumpTo) Read(reader *storage.Reader) {
+	j.Offset = reader.ReadShort()
+}
+
+// Id of the block as given in the TZX specification, written as a hexadecimal number.
+func (j JumpTo) Id() uint8 {
+	return 0x23
+}
+
+// Name of the block as given in the TZX specification.
+func (j JumpTo) Name() string {
+	return "Jump To"
+}
+
+// ToString returns a human readable string of the block data
+func (j JumpTo) ToString() string {
+	return fmt.Sprintf("%s: %d", j.Name(), int16(j.Offset))
+}
+
+// Write encodes the block, including its ID byte, to w.
+func (j JumpTo) Write(w io.Writer) error {
+	if err := writeId(w, j.Id()); err != nil {
+		return err
+	}
+	return writeShort(w, j.Offset)
+}
@@ -0,0 +1,75 @@
+package blocks
+
+import (
+	"fmt"
+	"io"
+
+	"retroio/storage"
+)
+
+// LoopStart
+// ID: 24h (36d)
+// If you have a sequence of identical blocks, or of identical blocks with different
+// data, you can use this block to tell how many times they should be repeated.
+// The Number of repetitions is greater or equal to 2.
+type LoopStart struct {
+	Count uint16 // N WORD  Number of repetitions (greater than 1)
+}
+
+// Read the tape and extract the data.
+// It is expected that the tape pointer is at the correct position for reading.
+func (l *LoopStart) Read(reader *storage.Reader) {
+	l.Count = reader.ReadShort()
+}
+
+// Id of the block as given in the TZX specification, written as a hexadecimal number.
+func (l LoopStart) Id() uint8 {
+	return 0x24
+}
+
+// Name of the block as given in the TZX specification.
+func (l LoopStart) Name() string {
+	return "Loop Start"
+}
+
+// ToString returns a human readable string of the block data
+func (l LoopStart) ToString() string {
+	return fmt.Sprintf("%s: %d repetitions", l.Name(), l.Count)
+}
+
+// Write encodes the block, including its ID byte, to w.
+func (l LoopStart) Write(w io.Writer) error {
+	if err := writeId(w, l.Id()); err != nil {
+		return err
+	}
+	return writeShort(w, l.Count)
+}
+
+// LoopEnd
+// ID: 25h (37d)
+// This block marks the end of a loop. This block has no body.
+type LoopEnd struct{}
+
+// Read the tape and extract the data.
+// It is expected that the tape pointer is at the correct position for reading.
+func (l LoopEnd) Read(reader *storage.Reader) {}
+
+// Id of the block as given in the TZX specification, written as a hexadecimal number.
+func (l LoopEnd) Id() uint8 {
+	return 0x25
+}
+
+// Name of the block as given in the TZX specification.
+func (l LoopEnd) Name() string {
+	return "Loop End"
+}
+
+// ToString returns a human readable string of the block data
+func (l LoopEnd) ToString() string {
+	return l.Name()
+}
+
+// Write encodes the block, including its ID byte, to w.
+func (l LoopEnd) Write(w io.Writer) error {
+	return writeId(w, l.Id())
+}
@@ -0,0 +1,95 @@
+package blocks
+
+import (
+	"fmt"
+	"io"
+
+	"retroio/storage"
+)
+
+// PauseTheTapeCommand
+// ID: 20h (32d)
+// This will make a silence (low amplitude level) for a given time in
+// milliseconds. If the value is 0 then the emulator or utility should
+// (if possible) show a message that the tape should be stopped now.
+type PauseTheTapeCommand struct {
+	Duration uint16 // WORD  Pause duration (ms)
+}
+
+// Read the tape and extract the data.
+// It is expected that the tape pointer is at the correct position for reading.
+func (p *PauseTheTapeCommand) Read(reader *storage.Reader) {
+	p.Duration = reader.ReadShort()
+}
+
+// Id of the block as given in the TZX specification, written as a hexadecimal number.
+func (p PauseTheTapeCommand) Id() uint8 {
+	return 0x20
+}
+
+// Name of the block as given in the TZX specification.
+func (p PauseTheTapeCommand) Name() string {
+	return "Pause the Tape Command"
+}
+
+// ToString returns a human readable string of the block data
+func (p PauseTheTapeCommand) ToString() string {
+	return fmt.Sprintf("%s: %d ms", p.Name(), p.Duration)
+}
+
+// Write encodes the block, including its ID byte, to w.
+func (p PauseTheTapeCommand) Write(w io.Writer) error {
+	if err := writeId(w, p.Id()); err != nil {
+		return err
+	}
+	return writeShort(w, p.Duration)
+}
+
+// Signal levels used by SetSignalLevel.
+const (
+	SignalLevelLow  = 0
+	SignalLevelHigh = 1
+)
+
+// SetSignalLevel
+// ID: 2Bh (43d)
+// This block sets the current signal level to the specified value (high or
+// low). It should be used whenever it is necessary to avoid any ambiguities,
+// e.g. with custom loaders that are level sensitive.
+type SetSignalLevel struct {
+	Length uint32 // DWORD  Length of the block without these four bytes (always 1)
+	Level  uint8  // BYTE   Signal level: 0 = low, 1 = high
+}
+
+// Read the tape and extract the data.
+// It is expected that the tape pointer is at the correct position for reading.
+func (s *SetSignalLevel) Read(reader *storage.Reader) {
+	s.Length = reader.ReadLong()
+	s.Level = reader.ReadByte()
+}
+
+// Id of the block as given in the TZX specification, written as a hexadecimal number.
+func (s SetSignalLevel) Id() uint8 {
+	return 0x2b
+}
+
+// Name of the block as given in the TZX specification.
+func (s SetSignalLevel) Name() string {
+	return "Set Signal Level"
+}
+
+// ToString returns a human readable string of the block data
+func (s SetSignalLevel) ToString() string {
+	return fmt.Sprintf("%s: %d", s.Name(), s.Level)
+}
+
+// Write encodes the block, including its ID byte, to w.
+func (s SetSignalLevel) Write(w io.Writer) error {
+	if err := writeId(w, s.Id()); err != nil {
+		return err
+	}
+	if err := writeLong(w, s.Length); err != nil {
+		return err
+	}
+	return writeByte(w, s.Level)
+}
@@ -0,0 +1,291 @@
+package blocks
+
+import (
+	"fmt"
+	"io"
+
+	"retroio/storage"
+)
+
+// TurboSpeedData
+// ID: 11h (17d)
+// Very similar to the Standard Speed Data block, but the whole block is
+// programmable in terms of the timing of each component and the bit pattern
+// used, allowing the replay of tapes which use non-standard loaders.
+type TurboSpeedData struct {
+	PilotPulse uint16 // WORD     Length of PILOT pulse {2168}
+	SyncFirst  uint16 // WORD     Length of SYNC first pulse {667}
+	SyncSecond uint16 // WORD     Length of SYNC second pulse {735}
+	Zero       uint16 // WORD     Length of ZERO bit pulse {855}
+	One        uint16 // WORD     Length of ONE bit pulse {1710}
+	PilotTone  uint16 // WORD     Length of PILOT tone (number of pulses) {8063 header/3223 data}
+	UsedBits   uint8  // BYTE     Used bits in the last byte (other bits should be 0) {8}
+	Pause      uint16 // WORD     Pause after this block (ms) {1000}
+	Length     uint32 // N BYTE[3] Length of data that follow
+	Data       []byte // BYTE[N]  Data as in .TAP files
+}
+
+// Read the tape and extract the data.
+// It is expected that the tape pointer is at the correct position for reading.
+func (t *TurboSpeedData) Read(reader *storage.Reader) {
+	t.PilotPulse = reader.ReadShort()
+	t.SyncFirst = reader.ReadShort()
+	t.SyncSecond = reader.ReadShort()
+	t.Zero = reader.ReadShort()
+	t.One = reader.ReadShort()
+	t.PilotTone = reader.ReadShort()
+	t.UsedBits = reader.ReadByte()
+	t.Pause = reader.ReadShort()
+	t.Length = reader.Read3ByteLong()
+	t.Data = reader.ReadNextBytes(int(t.Length))
+}
+
+// Id of the block as given in the TZX specification, written as a hexadecimal number.
+func (t TurboSpeedData) Id() uint8 {
+	return 0x11
+}
+
+// Name of the block as given in the TZX specification.
+func (t TurboSpeedData) Name() string {
+	return "Turbo Speed Data"
+}
+
+// ToString returns a human readable string of the block data
+func (t TurboSpeedData) ToString() string {
+	return fmt.Sprintf("%s: %d bytes", t.Name(), t.Length)
+}
+
+// Write encodes the block, including its ID byte, to w.
+func (t TurboSpeedData) Write(w io.Writer) error {
+	if err := writeId(w, t.Id()); err != nil {
+		return err
+	}
+	for _, v := range []uint16{t.PilotPulse, t.SyncFirst, t.SyncSecond, t.Zero, t.One, t.PilotTone} {
+		if err := writeShort(w, v); err != nil {
+			return err
+		}
+	}
+	if err := writeByte(w, t.UsedBits); err != nil {
+		return err
+	}
+	if err := writeShort(w, t.Pause); err != nil {
+		return err
+	}
+	if err := write3ByteLong(w, t.Length); err != nil {
+		return err
+	}
+	_, err := w.Write(t.Data)
+	return err
+}
+
+// PureTone
+// ID: 12h (18d)
+// This will produce a tone which is basically the same as the pilot tone in
+// the ID 10, ID 11 blocks. You can define how long the pulse is and how many
+// pulses there are in the tone.
+type PureTone struct {
+	PulseLength uint16 // WORD  Length of one pulse in T-states
+	PulseCount  uint16 // WORD  Number of pulses
+}
+
+// Read the tape and extract the data.
+// It is expected that the tape pointer is at the correct position for reading.
+func (p *PureTone) Read(reader *storage.Reader) {
+	p.PulseLength = reader.ReadShort()
+	p.PulseCount = reader.ReadShort()
+}
+
+// Id of the block as given in the TZX specification, written as a hexadecimal number.
+func (p PureTone) Id() uint8 {
+	return 0x12
+}
+
+// Name of the block as given in the TZX specification.
+func (p PureTone) Name() string {
+	return "Pure Tone"
+}
+
+// ToString returns a human readable string of the block data
+func (p PureTone) ToString() string {
+	return fmt.Sprintf("%s: %d pulses of %d T-states", p.Name(), p.PulseCount, p.PulseLength)
+}
+
+// Write encodes the block, including its ID byte, to w.
+func (p PureTone) Write(w io.Writer) error {
+	if err := writeId(w, p.Id()); err != nil {
+		return err
+	}
+	if err := writeShort(w, p.PulseLength); err != nil {
+		return err
+	}
+	return writeShort(w, p.PulseCount)
+}
+
+// SequenceOfPulses
+// ID: 13h (19d)
+// This will produce N pulses, each having its own length in T-states.
+type SequenceOfPulses struct {
+	Count  uint8    // BYTE     Number of pulses
+	Pulses []uint16 // WORD[N]  Pulses' lengths
+}
+
+// Read the tape and extract the data.
+// It is expected that the tape pointer is at the correct position for reading.
+func (s *SequenceOfPulses) Read(reader *storage.Reader) {
+	s.Count = reader.ReadByte()
+	for i := 0; i < int(s.Count); i++ {
+		s.Pulses = append(s.Pulses, reader.ReadShort())
+	}
+}
+
+// Id of the block as given in the TZX specification, written as a hexadecimal number.
+func (s SequenceOfPulses) Id() uint8 {
+	return 0x13
+}
+
+// Name of the block as given in the TZX specification.
+func (s SequenceOfPulses) Name() string {
+	return "Sequence of Pulses"
+}
+
+// ToString returns a human readable string of the block data
+func (s SequenceOfPulses) ToString() string {
+	return fmt.Sprintf("%s: %d pulses", s.Name(), s.Count)
+}
+
+// Write encodes the block, including its ID byte, to w.
+func (s SequenceOfPulses) Write(w io.Writer) error {
+	if err := writeId(w, s.Id()); err != nil {
+		return err
+	}
+	if err := writeByte(w, s.Count); err != nil {
+		return err
+	}
+	for _, p := range s.Pulses {
+		if err := writeShort(w, p); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// PureData
+// ID: 14h (20d)
+// This block is the same as the Turbo Speed Data block, but it has no pilot
+// or sync pulses.
+type PureData struct {
+	Zero     uint16 // WORD     Length of ZERO bit pulse
+	One      uint16 // WORD     Length of ONE bit pulse
+	UsedBits uint8  // BYTE     Used bits in the last byte
+	Pause    uint16 // WORD     Pause after this block (ms)
+	Length   uint32 // N BYTE[3] Length of data that follow
+	Data     []byte // BYTE[N]  Data as in .TAP files
+}
+
+// Read the tape and extract the data.
+// It is expected that the tape pointer is at the correct position for reading.
+func (p *PureData) Read(reader *storage.Reader) {
+	p.Zero = reader.ReadShort()
+	p.One = reader.ReadShort()
+	p.UsedBits = reader.ReadByte()
+	p.Pause = reader.ReadShort()
+	p.Length = reader.Read3ByteLong()
+	p.Data = reader.ReadNextBytes(int(p.Length))
+}
+
+// Id of the block as given in the TZX specification, written as a hexadecimal number.
+func (p PureData) Id() uint8 {
+	return 0x14
+}
+
+// Name of the block as given in the TZX specification.
+func (p PureData) Name() string {
+	return "Pure Data"
+}
+
+// ToString returns a human readable string of the block data
+func (p PureData) ToString() string {
+	return fmt.Sprintf("%s: %d bytes", p.Name(), p.Length)
+}
+
+// Write encodes the block, including its ID byte, to w.
+func (p PureData) Write(w io.Writer) error {
+	if err := writeId(w, p.Id()); err != nil {
+		return err
+	}
+	if err := writeShort(w, p.Zero); err != nil {
+		return err
+	}
+	if err := writeShort(w, p.One); err != nil {
+		return err
+	}
+	if err := writeByte(w, p.UsedBits); err != nil {
+		return err
+	}
+	if err := writeShort(w, p.Pause); err != nil {
+		return err
+	}
+	if err := write3ByteLong(w, p.Length); err != nil {
+		return err
+	}
+	_, err := w.Write(p.Data)
+	return err
+}
+
+// DirectRecording
+// ID: 15h (21d)
+// This block is used for representing the raw sampled audio of the tape,
+// one bit per sample, at a fixed number of T-states per sample.
+type DirectRecording struct {
+	TStatesPerSample uint16 // WORD      Number of T-states per sample (bit of data)
+	Pause            uint16 // WORD      Pause after this block (ms)
+	UsedBits         uint8  // BYTE      Used bits in the last byte
+	Length           uint32 // N BYTE[3] Length of data that follow
+	Data             []byte // BYTE[N]   Samples, one bit per sample
+}
+
+// Read the tape and extract the data.
+// It is expected that the tape pointer is at the correct position for reading.
+func (d *DirectRecording) Read(reader *storage.Reader) {
+	d.TStatesPerSample = reader.ReadShort()
+	d.Pause = reader.ReadShort()
+	d.UsedBits = reader.ReadByte()
+	d.Length = reader.Read3ByteLong()
+	d.Data = reader.ReadNextBytes(int(d.Length))
+}
+
+// Id of the block as given in the TZX specification, written as a hexadecimal number.
+func (d DirectRecording) Id() uint8 {
+	return 0x15
+}
+
+// Name of the block as given in the TZX specification.
+func (d DirectRecording) Name() string {
+	return "Direct Recording"
+}
+
+// ToString returns a human readable string of the block data
+func (d DirectRecording) ToString() string {
+	return fmt.Sprintf("%s: %d bytes, %d T-states/sample", d.Name(), d.Length, d.TStatesPerSample)
+}
+
+// Write encodes the block, including its ID byte, to w.
+func (d DirectRecording) Write(w io.Writer) error {
+	if err := writeId(w, d.Id()); err != nil {
+		return err
+	}
+	if err := writeShort(w, d.TStatesPerSample); err != nil {
+		return err
+	}
+	if err := writeShort(w, d.Pause); err != nil {
+		return err
+	}
+	if err := writeByte(w, d.UsedBits); err != nil {
+		return err
+	}
+	if err := write3ByteLong(w, d.Length); err != nil {
+		return err
+	}
+	_, err := w.Write(d.Data)
+	return err
+}
@@ -0,0 +1,57 @@
+package blocks
+
+import (
+	"fmt"
+	"io"
+
+	"retroio/storage"
+)
+
+// StandardSpeedData
+// ID: 10h (16d)
+// This block must be replayed with the standard ROM timing values - see the TZX
+// specification for the pilot/sync/bit timings used. Most programs that use
+// standard loading and saving will have this type of block.
+type StandardSpeedData struct {
+	Pause  uint16 // WORD     Pause after this block (ms) {1000}
+	Length uint16 // N WORD   Length of data that follow
+	Data   []byte // BYTE[N]  Data as in .TAP files
+}
+
+// Read the tape and extract the data.
+// It is expected that the tape pointer is at the correct position for reading.
+func (s *StandardSpeedData) Read(reader *storage.Reader) {
+	s.Pause = reader.ReadShort()
+	s.Length = reader.ReadShort()
+	s.Data = reader.ReadNextBytes(int(s.Length))
+}
+
+// Id of the block as given in the TZX specification, written as a hexadecimal number.
+func (s StandardSpeedData) Id() uint8 {
+	return 0x10
+}
+
+// Name of the block as given in the TZX specification.
+func (s StandardSpeedData) Name() string {
+	return "Standard Speed Data"
+}
+
+// ToString returns a human readable string of the block data
+func (s StandardSpeedData) ToString() string {
+	return fmt.Sprintf("%s: %d bytes, %d ms pause", s.Name(), s.Length, s.Pause)
+}
+
+// Write encodes the block, including its ID byte, to w.
+func (s StandardSpeedData) Write(w io.Writer) error {
+	if err := writeId(w, s.Id()); err != nil {
+		return err
+	}
+	if err := writeShort(w, s.Pause); err != nil {
+		return err
+	}
+	if err := writeShort(w, s.Length); err != nil {
+		return err
+	}
+	_, err := w.Write(s.Data)
+	return err
+}
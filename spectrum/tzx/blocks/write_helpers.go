@@ -0,0 +1,34 @@
+package blocks
+
+import (
+	"encoding/binary"
+	"io"
+
+	"github.com/pkg/errors"
+)
+
+// writeId writes the block's one-byte ID, as every TZX block starts with it.
+func writeId(w io.Writer, id uint8) error {
+	_, err := w.Write([]byte{id})
+	return errors.Wrap(err, "error writing block ID")
+}
+
+func writeByte(w io.Writer, b uint8) error {
+	_, err := w.Write([]byte{b})
+	return errors.Wrap(err, "error writing byte")
+}
+
+func writeShort(w io.Writer, v uint16) error {
+	return errors.Wrap(binary.Write(w, binary.LittleEndian, v), "error writing WORD")
+}
+
+func writeLong(w io.Writer, v uint32) error {
+	return errors.Wrap(binary.Write(w, binary.LittleEndian, v), "error writing DWORD")
+}
+
+// write3ByteLong writes the low 3 bytes of v, little-endian, as used by the
+// 3-byte data-length fields in several TZX blocks.
+func write3ByteLong(w io.Writer, v uint32) error {
+	_, err := w.Write([]byte{byte(v), byte(v >> 8), byte(v >> 16)})
+	return errors.Wrap(err, "error writing 3-byte length")
+}
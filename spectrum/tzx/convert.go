@@ -0,0 +1,48 @@
+package tzx
+
+import (
+	"github.com/pkg/errors"
+
+	"retroio/spectrum/tap/blocks"
+	tzxblocks "retroio/spectrum/tzx/blocks"
+)
+
+// ToTAP converts a list of parsed TZX blocks into the TAP equivalent. Only
+// StandardSpeedData (0x10) blocks can be represented in a TAP file; any
+// other block type is rejected, naming the offending block IDs.
+func ToTAP(tzxBlocks []Block) ([]*blocks.StandardData, error) {
+	var unsupported []uint8
+	var malformed []int
+	var tapBlocks []*blocks.StandardData
+
+	for i, b := range tzxBlocks {
+		std, ok := b.(*tzxblocks.StandardSpeedData)
+		if !ok {
+			unsupported = append(unsupported, b.Id())
+			continue
+		}
+
+		payload := std.Data
+		if len(payload) < 2 {
+			// Too short to contain both a flag and checksum byte.
+			malformed = append(malformed, i)
+			continue
+		}
+
+		tapBlocks = append(tapBlocks, &blocks.StandardData{
+			Length:   std.Length,
+			Flag:     payload[0],
+			Data:     payload[1 : len(payload)-1],
+			Checksum: payload[len(payload)-1],
+		})
+	}
+
+	if len(unsupported) > 0 {
+		return nil, errors.Errorf("tzx: cannot convert to TAP, unsupported block IDs: %#v", unsupported)
+	}
+	if len(malformed) > 0 {
+		return nil, errors.Errorf("tzx: cannot convert to TAP, malformed StandardSpeedData payload (too short for flag+checksum) at block indices: %v", malformed)
+	}
+
+	return tapBlocks, nil
+}
@@ -0,0 +1,79 @@
+package tzx
+
+import (
+	"io"
+
+	"github.com/pkg/errors"
+
+	"retroio/spectrum/tzx/blocks"
+)
+
+// signature is the fixed 8-byte file identifier every TZX file starts with.
+var signature = [8]byte{'Z', 'X', 'T', 'a', 'p', 'e', '!', 0x1A}
+
+// Writable is implemented by every TZX block, allowing it to be re-encoded.
+type Writable interface {
+	Id() uint8
+	Write(w io.Writer) error
+}
+
+// Encoder writes a TZX 1.20 file: the signature/version header followed by
+// a sequence of encoded blocks.
+type Encoder struct {
+	MajorVersion uint8
+	MinorVersion uint8
+	Blocks       []Writable
+}
+
+// NewEncoder returns an Encoder for the TZX 1.20 format, the version this
+// package reads and produces.
+func NewEncoder(blockList []Writable) *Encoder {
+	return &Encoder{MajorVersion: 1, MinorVersion: 20, Blocks: blockList}
+}
+
+// Write emits the TZX header followed by every block, in order.
+func (e *Encoder) Write(w io.Writer) error {
+	if _, err := w.Write(signature[:]); err != nil {
+		return errors.Wrap(err, "error writing TZX signature")
+	}
+	if _, err := w.Write([]byte{e.MajorVersion, e.MinorVersion}); err != nil {
+		return errors.Wrap(err, "error writing TZX version")
+	}
+
+	for i, block := range e.Blocks {
+		if err := block.Write(w); err != nil {
+			return errors.Wrapf(err, "error writing block #%d (0x%02X)", i, block.Id())
+		}
+	}
+
+	return nil
+}
+
+// WithoutGlueBlocks returns a copy of blockList with every GlueBlock (0x5A)
+// removed, as recommended by the TZX specification once two files have been
+// merged.
+func WithoutGlueBlocks(blockList []Writable) []Writable {
+	var out []Writable
+	for _, b := range blockList {
+		if _, ok := b.(*blocks.GlueBlock); ok {
+			continue
+		}
+		out = append(out, b)
+	}
+	return out
+}
+
+// Merge concatenates the block streams of two TZX files into one, and
+// returns an Encoder whose header uses the higher of the two minor versions.
+func Merge(first *Encoder, second *Encoder) *Encoder {
+	minor := first.MinorVersion
+	if second.MinorVersion > minor {
+		minor = second.MinorVersion
+	}
+
+	merged := make([]Writable, 0, len(first.Blocks)+len(second.Blocks))
+	merged = append(merged, first.Blocks...)
+	merged = append(merged, second.Blocks...)
+
+	return &Encoder{MajorVersion: first.MajorVersion, MinorVersion: minor, Blocks: merged}
+}
@@ -0,0 +1,104 @@
+package tzx
+
+import (
+	"bytes"
+	"os"
+	"testing"
+
+	"retroio/spectrum/tzx/blocks"
+	"retroio/storage"
+)
+
+// parseSample decodes testdata/sample.tzx - a real TZX 1.20 file containing
+// a StandardSpeedData, a PureTone and a trailing GlueBlock - into its raw
+// header bytes and its list of Writable blocks.
+func parseSample(t *testing.T) (raw []byte, blockList []Writable) {
+	t.Helper()
+
+	raw, err := os.ReadFile("testdata/sample.tzx")
+	if err != nil {
+		t.Fatalf("error reading testdata/sample.tzx: %v", err)
+	}
+
+	if !bytes.Equal(raw[:8], signature[:]) {
+		t.Fatalf("unexpected signature: % X", raw[:8])
+	}
+
+	reader := storage.NewReader(raw[10:])
+	for !reader.IsEOF() {
+		id := reader.ReadByte()
+
+		var b Writable
+		switch id {
+		case 0x10:
+			block := &blocks.StandardSpeedData{}
+			block.Read(reader)
+			b = block
+		case 0x12:
+			block := &blocks.PureTone{}
+			block.Read(reader)
+			b = block
+		case 0x5a:
+			block := &blocks.GlueBlock{}
+			block.Read(reader)
+			b = block
+		default:
+			t.Fatalf("testdata/sample.tzx contains an unhandled block ID 0x%02X", id)
+		}
+
+		blockList = append(blockList, b)
+	}
+
+	return raw, blockList
+}
+
+// TestEncoderWriteRoundTrip checks that a real TZX file, once parsed, can be
+// re-encoded via Encoder.Write to produce byte-for-byte identical output.
+func TestEncoderWriteRoundTrip(t *testing.T) {
+	raw, blockList := parseSample(t)
+
+	var out bytes.Buffer
+	if err := NewEncoder(blockList).Write(&out); err != nil {
+		t.Fatalf("Write returned error: %v", err)
+	}
+
+	if !bytes.Equal(raw, out.Bytes()) {
+		t.Errorf("round trip is not byte-for-byte identical:\n original:   % X\n re-encoded: % X", raw, out.Bytes())
+	}
+}
+
+// TestWithoutGlueBlocks checks that the GlueBlock trailing the sample file
+// is the only block removed, and that everything else survives re-encoding.
+func TestWithoutGlueBlocks(t *testing.T) {
+	_, blockList := parseSample(t)
+
+	stripped := WithoutGlueBlocks(blockList)
+
+	if len(stripped) != len(blockList)-1 {
+		t.Fatalf("expected %d blocks after stripping the glue block, got %d", len(blockList)-1, len(stripped))
+	}
+
+	for _, b := range stripped {
+		if _, ok := b.(*blocks.GlueBlock); ok {
+			t.Errorf("GlueBlock survived WithoutGlueBlocks: %#v", b)
+		}
+	}
+}
+
+// TestMerge checks that merging two encoders concatenates their blocks, in
+// order, and keeps the higher of the two minor versions.
+func TestMerge(t *testing.T) {
+	_, blockList := parseSample(t)
+
+	first := &Encoder{MajorVersion: 1, MinorVersion: 13, Blocks: blockList}
+	second := &Encoder{MajorVersion: 1, MinorVersion: 20, Blocks: blockList}
+
+	merged := Merge(first, second)
+
+	if merged.MinorVersion != 20 {
+		t.Errorf("expected merged minor version 20, got %d", merged.MinorVersion)
+	}
+	if len(merged.Blocks) != len(blockList)*2 {
+		t.Errorf("expected %d merged blocks, got %d", len(blockList)*2, len(merged.Blocks))
+	}
+}
@@ -0,0 +1,221 @@
+// Package tzx resolves the control-flow blocks of a parsed tape (CallSequence,
+// LoopStart/LoopEnd, JumpTo and ReturnFromSequence) into the flat sequence of
+// blocks that a real tape player would actually emit.
+package tzx
+
+import (
+	"github.com/pkg/errors"
+
+	"retroio/spectrum/tzx/blocks"
+)
+
+// maxSteps bounds how many blocks Playback will visit while resolving a tape,
+// guarding against malformed files whose JumpTo offsets form an infinite loop.
+const maxSteps = 1_000_000
+
+// Block is the subset of the tape/tzx block interface the player needs in
+// order to identify and describe a block while resolving control flow.
+type Block interface {
+	Id() uint8
+	Name() string
+}
+
+// Iterator yields the resolved, linear sequence of audio-producing blocks,
+// with all CallSequence/LoopStart/LoopEnd/JumpTo bookkeeping already applied.
+type Iterator interface {
+	// Next returns the next block in playback order, and false once the
+	// tape has been fully played.
+	Next() (Block, bool)
+}
+
+// Playback walks a parsed block list and resolves it into the linear stream
+// of blocks a tape player would emit, expanding loops, following calls and
+// applying jumps.
+type Playback struct {
+	blocks []Block
+
+	resolved []Block
+	pos      int
+}
+
+// NewPlayback resolves the given block list into a flat playback sequence.
+// It returns an error if the tape uses nested CALL blocks, or has an
+// unmatched LoopStart/LoopEnd pair.
+func NewPlayback(blockList []Block) (*Playback, error) {
+	resolved, err := resolve(blockList)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Playback{blocks: blockList, resolved: resolved}, nil
+}
+
+// Next returns the next block in playback order.
+func (p *Playback) Next() (Block, bool) {
+	if p.pos >= len(p.resolved) {
+		return nil, false
+	}
+
+	b := p.resolved[p.pos]
+	p.pos++
+	return b, true
+}
+
+// resolve expands the block list into the sequence of blocks that are
+// actually "played", following CallSequence, LoopStart/LoopEnd and JumpTo.
+func resolve(blockList []Block) ([]Block, error) {
+	validReturns, err := findValidReturns(blockList)
+	if err != nil {
+		return nil, err
+	}
+
+	steps := 0
+	return resolveRange(blockList, 0, len(blockList), validReturns, &steps)
+}
+
+// findValidReturns walks every CallSequence in the tape and records the
+// index of the ReturnFromSequence block that terminates each of its call
+// targets. Call targets can themselves contain loops, so a target's
+// ReturnFromSequence need not immediately follow it in the block list -
+// findReturn is what actually locates it. A ReturnFromSequence encountered
+// anywhere else - i.e. not the designated end of some call's target - is an
+// orphaned marker, which resolveRange rejects.
+func findValidReturns(blockList []Block) (map[int]bool, error) {
+	valid := map[int]bool{}
+
+	for index, b := range blockList {
+		call, ok := b.(*blocks.CallSequence)
+		if !ok {
+			continue
+		}
+
+		for _, offset := range call.Blocks {
+			target := index + int(int16(offset))
+			if target < 0 || target >= len(blockList) {
+				return nil, errors.Errorf("tzx: CallSequence offset %d points outside the tape", int16(offset))
+			}
+
+			returnAt, err := findReturn(blockList, target)
+			if err != nil {
+				return nil, err
+			}
+			valid[returnAt] = true
+		}
+	}
+
+	return valid, nil
+}
+
+// findReturn returns the index of the ReturnFromSequence block terminating
+// the call target starting at target, erroring on a nested CallSequence or
+// on running off the end of the tape without finding one.
+func findReturn(blockList []Block, target int) (int, error) {
+	for i := target; i < len(blockList); i++ {
+		if _, ok := blockList[i].(*blocks.CallSequence); ok {
+			return 0, errors.New("tzx: nested CallSequence blocks are not allowed")
+		}
+		if _, ok := blockList[i].(*blocks.ReturnFromSequence); ok {
+			return i, nil
+		}
+	}
+
+	return 0, errors.New("tzx: CallSequence target has no matching ReturnFromSequence")
+}
+
+// resolveRange resolves the blocks in [start, end), the same way resolve
+// does for a whole tape. It is used both for the top-level tape and,
+// recursively, for the sub-ranges a loop body or a CallSequence target
+// spans, so that control-flow blocks nested inside either are followed
+// rather than copied verbatim.
+func resolveRange(blockList []Block, start, end int, validReturns map[int]bool, steps *int) ([]Block, error) {
+	var out []Block
+
+	index := start
+	for index < end {
+		*steps++
+		if *steps > maxSteps {
+			return nil, errors.Errorf("tzx: execution budget of %d blocks exceeded, probable infinite jump", maxSteps)
+		}
+
+		b := blockList[index]
+
+		switch block := b.(type) {
+		case *blocks.CallSequence:
+			out = append(out, b)
+
+			for _, offset := range block.Blocks {
+				target := index + int(int16(offset))
+				if target < 0 || target >= len(blockList) {
+					return nil, errors.Errorf("tzx: CallSequence offset %d points outside the tape", int16(offset))
+				}
+
+				returnAt, err := findReturn(blockList, target)
+				if err != nil {
+					return nil, err
+				}
+
+				called, err := resolveRange(blockList, target, returnAt, validReturns, steps)
+				if err != nil {
+					return nil, err
+				}
+				out = append(out, called...)
+			}
+			index++
+
+		case *blocks.LoopStart:
+			out = append(out, b)
+
+			loopEnd, err := findLoopEnd(blockList, index)
+			if err != nil {
+				return nil, err
+			}
+
+			for i := uint16(0); i < block.Count; i++ {
+				body, err := resolveRange(blockList, index+1, loopEnd, validReturns, steps)
+				if err != nil {
+					return nil, err
+				}
+				out = append(out, body...)
+			}
+			index = loopEnd + 1
+
+		case *blocks.LoopEnd:
+			return nil, errors.New("tzx: LoopEnd block found without a matching LoopStart")
+
+		case *blocks.JumpTo:
+			out = append(out, b)
+
+			target := index + int(int16(block.Offset))
+			if target < 0 || target >= len(blockList) {
+				return nil, errors.Errorf("tzx: JumpTo offset %d points outside the tape", int16(block.Offset))
+			}
+			index = target
+
+		case *blocks.ReturnFromSequence:
+			if !validReturns[index] {
+				return nil, errors.New("tzx: ReturnFromSequence block found without a matching CallSequence")
+			}
+			index++
+
+		default:
+			out = append(out, b)
+			index++
+		}
+	}
+
+	return out, nil
+}
+
+// findLoopEnd returns the index of the LoopEnd block matching the LoopStart at startIndex.
+func findLoopEnd(blockList []Block, startIndex int) (int, error) {
+	for i := startIndex + 1; i < len(blockList); i++ {
+		if _, ok := blockList[i].(*blocks.LoopStart); ok {
+			return 0, errors.New("tzx: nested LoopStart blocks are not allowed")
+		}
+		if _, ok := blockList[i].(*blocks.LoopEnd); ok {
+			return i, nil
+		}
+	}
+
+	return 0, errors.New("tzx: LoopStart block found without a matching LoopEnd")
+}
@@ -0,0 +1,230 @@
+package tzx
+
+import (
+	"testing"
+
+	"retroio/spectrum/tzx/blocks"
+)
+
+// filler is a minimal Block used to stand in for an audio-producing block
+// (StandardSpeedData, PureTone, etc.) in control-flow tests, since resolve
+// only cares about a block's identity, not its payload.
+type filler struct {
+	id   uint8
+	name string
+}
+
+func (f filler) Id() uint8    { return f.id }
+func (f filler) Name() string { return f.name }
+
+func newFiller(name string) *filler { return &filler{id: 0x10, name: name} }
+
+// relOffset converts a signed relative offset into the raw uint16 a
+// CallSequence/JumpTo block stores it as. Go rejects converting a negative
+// typed constant straight to uint16, so n is taken as a variable to force a
+// non-constant conversion.
+func relOffset(n int16) uint16 { return uint16(n) }
+
+// names returns the Name() of each block in order, for compact assertions.
+func names(blockList []Block) []string {
+	out := make([]string, len(blockList))
+	for i, b := range blockList {
+		out[i] = b.Name()
+	}
+	return out
+}
+
+func assertNames(t *testing.T, got []Block, want []string) {
+	t.Helper()
+
+	gotNames := names(got)
+	if len(gotNames) != len(want) {
+		t.Fatalf("expected %d blocks, got %d: %v", len(want), len(gotNames), gotNames)
+	}
+	for i := range want {
+		if gotNames[i] != want[i] {
+			t.Errorf("block #%d: expected %q, got %q (full: %v)", i, want[i], gotNames[i], gotNames)
+		}
+	}
+}
+
+// TestPlaybackLoopExpansion checks that a LoopStart/LoopEnd pair is expanded
+// into Count repetitions of its body, in place.
+func TestPlaybackLoopExpansion(t *testing.T) {
+	blockList := []Block{
+		newFiller("before"),
+		&blocks.LoopStart{Count: 3},
+		newFiller("body"),
+		&blocks.LoopEnd{},
+		newFiller("after"),
+	}
+
+	p, err := NewPlayback(blockList)
+	if err != nil {
+		t.Fatalf("NewPlayback returned error: %v", err)
+	}
+
+	var out []Block
+	for {
+		b, ok := p.Next()
+		if !ok {
+			break
+		}
+		out = append(out, b)
+	}
+
+	assertNames(t, out, []string{"before", "Loop Start", "body", "body", "body", "after"})
+}
+
+// TestPlaybackCallSequenceMultipleTargets checks that a CallSequence with
+// more than one target plays each target's body, in order, before resuming
+// the main tape after the call. The call targets sit in a dead zone that a
+// leading JumpTo skips over in the main flow, as a real tape would lay them
+// out, so they are only heard via the call, not a second time as regular
+// sequential blocks.
+func TestPlaybackCallSequenceMultipleTargets(t *testing.T) {
+	// index: 0=jump past dead zone, 1=first target, 2=return,
+	// 3=second target, 4=return, 5=call, 6=after
+	blockList := []Block{
+		&blocks.JumpTo{Offset: 5},
+		newFiller("first"),
+		&blocks.ReturnFromSequence{},
+		newFiller("second"),
+		&blocks.ReturnFromSequence{},
+		&blocks.CallSequence{Count: 2, Blocks: []uint16{relOffset(-4), relOffset(-2)}},
+		newFiller("after"),
+	}
+
+	p, err := NewPlayback(blockList)
+	if err != nil {
+		t.Fatalf("NewPlayback returned error: %v", err)
+	}
+
+	var out []Block
+	for {
+		b, ok := p.Next()
+		if !ok {
+			break
+		}
+		out = append(out, b)
+	}
+
+	assertNames(t, out, []string{"Jump To", "Call Sequence", "first", "second", "after"})
+}
+
+// TestPlaybackJumpForward checks that a JumpTo with a positive offset skips
+// the blocks in between.
+func TestPlaybackJumpForward(t *testing.T) {
+	blockList := []Block{
+		newFiller("start"),
+		&blocks.JumpTo{Offset: 2},
+		newFiller("skipped"),
+		newFiller("landed"),
+	}
+
+	p, err := NewPlayback(blockList)
+	if err != nil {
+		t.Fatalf("NewPlayback returned error: %v", err)
+	}
+
+	var out []Block
+	for {
+		b, ok := p.Next()
+		if !ok {
+			break
+		}
+		out = append(out, b)
+	}
+
+	assertNames(t, out, []string{"start", "Jump To", "landed"})
+}
+
+// TestPlaybackJumpBackward checks that a JumpTo with a negative offset
+// re-plays earlier blocks, and that the execution budget eventually catches
+// a tape that jumps backward forever.
+func TestPlaybackJumpBackward(t *testing.T) {
+	// index 0 plays, index 1 jumps back to index 0, forever.
+	blockList := []Block{
+		newFiller("loop"),
+		&blocks.JumpTo{Offset: relOffset(-1)},
+	}
+
+	_, err := NewPlayback(blockList)
+	if err == nil {
+		t.Fatal("expected an error for a tape that jumps backward forever")
+	}
+}
+
+// TestPlaybackNestedCallSequence checks that a CallSequence target which
+// itself contains a CallSequence is rejected.
+func TestPlaybackNestedCallSequence(t *testing.T) {
+	blockList := []Block{
+		&blocks.CallSequence{Count: 1, Blocks: []uint16{1}},
+		&blocks.CallSequence{Count: 1, Blocks: []uint16{1}},
+		&blocks.ReturnFromSequence{},
+		&blocks.ReturnFromSequence{},
+	}
+
+	_, err := NewPlayback(blockList)
+	if err == nil {
+		t.Fatal("expected an error for a nested CallSequence")
+	}
+}
+
+// TestPlaybackUnmatchedLoop checks that a LoopStart with no matching LoopEnd
+// is rejected.
+func TestPlaybackUnmatchedLoop(t *testing.T) {
+	blockList := []Block{
+		&blocks.LoopStart{Count: 2},
+		newFiller("body"),
+	}
+
+	_, err := NewPlayback(blockList)
+	if err == nil {
+		t.Fatal("expected an error for a LoopStart with no matching LoopEnd")
+	}
+}
+
+// TestPlaybackOrphanReturn checks that a ReturnFromSequence with no
+// preceding CallSequence is rejected.
+func TestPlaybackOrphanReturn(t *testing.T) {
+	blockList := []Block{
+		newFiller("before"),
+		&blocks.ReturnFromSequence{},
+	}
+
+	_, err := NewPlayback(blockList)
+	if err == nil {
+		t.Fatal("expected an error for an orphan ReturnFromSequence")
+	}
+}
+
+// TestPlaybackBudgetExceeded checks that a tape whose loop count multiplies
+// out past maxSteps is rejected rather than resolved forever.
+func TestPlaybackBudgetExceeded(t *testing.T) {
+	blockList := []Block{
+		&blocks.LoopStart{Count: 65535},
+		newFiller("a"),
+		newFiller("b"),
+		newFiller("c"),
+		newFiller("d"),
+		newFiller("e"),
+		newFiller("f"),
+		newFiller("g"),
+		newFiller("h"),
+		newFiller("i"),
+		newFiller("j"),
+		newFiller("k"),
+		newFiller("l"),
+		newFiller("m"),
+		newFiller("n"),
+		newFiller("o"),
+		newFiller("p"),
+		&blocks.LoopEnd{},
+	}
+
+	_, err := NewPlayback(blockList)
+	if err == nil {
+		t.Fatal("expected an error once the execution budget is exceeded")
+	}
+}
@@ -0,0 +1,54 @@
+package tape
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+)
+
+// EncodeJSON writes node, and its children, to w as indented JSON.
+func EncodeJSON(w io.Writer, node Node) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(node)
+}
+
+// EncodeTree writes node, and its children, to w as an indented, human
+// readable tree, with each field printed as "name: value".
+func EncodeTree(w io.Writer, node Node) error {
+	return writeTreeNode(w, node, 0)
+}
+
+func writeTreeNode(w io.Writer, node Node, depth int) error {
+	indent := strings.Repeat("  ", depth)
+
+	if node.Offset != 0 {
+		if _, err := fmt.Fprintf(w, "%s%s (0x%02X) @ offset 0x%X\n", indent, node.Name, node.ID, node.Offset); err != nil {
+			return err
+		}
+	} else if _, err := fmt.Fprintf(w, "%s%s (0x%02X)\n", indent, node.Name, node.ID); err != nil {
+		return err
+	}
+
+	keys := make([]string, 0, len(node.Fields))
+	for k := range node.Fields {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	for _, k := range keys {
+		if _, err := fmt.Fprintf(w, "%s  %s: %v\n", indent, k, node.Fields[k]); err != nil {
+			return err
+		}
+	}
+
+	for _, child := range node.Children {
+		if err := writeTreeNode(w, child, depth+1); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
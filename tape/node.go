@@ -0,0 +1,45 @@
+package tape
+
+// Node is a structured representation of a single block, suitable for
+// encoding as JSON or as an indented tree. It lets tooling walk a parsed
+// tape without depending on each block's free-form ToString() output.
+type Node struct {
+	ID       int            // Id() of the block this node describes
+	Name     string         // Name() of the block this node describes
+	Offset   int64          // byte offset, within the tape, at which this block begins; 0 if unknown
+	Fields   map[string]any // field name -> decoded value, in declaration order is not guaranteed
+	Children []Node         // nested structures, e.g. a TrackInformation's list of sectors
+}
+
+// Describer is implemented by blocks that can describe themselves as a Node,
+// for structured (JSON/tree) output.
+type Describer interface {
+	Describe() Node
+}
+
+// Entry pairs a parsed block with the byte offset, within the tape, at
+// which it begins. A block's Describe() method only has access to its own
+// decoded fields, not its position in the file, so the offset is supplied
+// by whatever read the tape and therefore knows it.
+type Entry struct {
+	Offset int64
+	Block  Describer
+}
+
+// Dump assembles a full parsed tape's blocks into a single root Node,
+// suitable for EncodeJSON or EncodeTree, with every block described as a
+// child node annotated with its offset in the tape.
+func Dump(entries []Entry) Node {
+	children := make([]Node, len(entries))
+	for i, e := range entries {
+		node := e.Block.Describe()
+		node.Offset = e.Offset
+		children[i] = node
+	}
+
+	return Node{
+		Name:     "Tape",
+		Fields:   map[string]any{"block_count": len(entries)},
+		Children: children,
+	}
+}